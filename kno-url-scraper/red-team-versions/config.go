@@ -0,0 +1,195 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// -------- Config file + profiles (-p / --profile) --------
+//
+// Common invocations are preset in an INI file so they don't need to be
+// retyped every session: ~/.kno-url/config.ini first, then ./kno-url.ini
+// (local keys win on conflict). A [default] section applies when no
+// profile is named; [profile.<name>] sections are selected with -p/--profile.
+// Profile flags are merged *under* the command line, i.e. the CLI args are
+// appended after them, so the existing "last flag wins" parsing in
+// parseHTMLFilters/parseCrawlFlags/parseNetworkFilters already gives CLI
+// precedence with no extra conflict-resolution logic needed.
+
+var (
+	configOnce     sync.Once
+	configSections map[string]map[string]string
+)
+
+// categoryShortFlag maps the short names used in a "categories=" config
+// line to the actual HTML-mode category flags.
+var categoryShortFlag = map[string]string{
+	"s": "-s", "md": "-md", "a": "-a", "d": "-d", "ht": "-ht", "o": "-O",
+}
+
+// iniKeyToFlag maps config keys to their CLI flag. Keys not listed here
+// (currently just "categories") need bespoke handling.
+var iniKeyToFlag = map[string]string{
+	"search":        "--search",
+	"insecure":      "--insecure",
+	"o":             "-o",
+	"ua":            "--ua",
+	"no_ua_refresh": "--no-ua-refresh",
+	"crawl":         "--crawl",
+	"crawl_scope":   "--crawl-scope",
+	"crawl_include": "--crawl-include",
+	"ignore_robots": "--ignore-robots",
+	"rps":           "--rps",
+	"concurrency":   "--concurrency",
+	"show_source":   "--show-source",
+	"max_pages":     "--max-pages",
+	"sitemap":       "--sitemap",
+	"sitemap_only":  "--sitemap-only",
+	"sitemap_max":   "--sitemap-max",
+	"format":        "--format",
+	"full":          "--full",
+	"no_media":      "--no-media",
+}
+
+// booleanIniFlags take no value; a truthy value ("true"/"1"/"yes") enables them.
+var booleanIniFlags = map[string]bool{
+	"--insecure": true, "--no-ua-refresh": true, "--ignore-robots": true,
+	"--show-source": true, "--sitemap": true, "--sitemap-only": true,
+	"--full": true, "--no-media": true,
+}
+
+func isTruthy(v string) bool {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "true", "1", "yes", "on":
+		return true
+	default:
+		return false
+	}
+}
+
+// parseINI is a minimal parser: "[section]" headers, "key = value" lines,
+// blank lines and "#"/";" comments ignored. Good enough for flag presets;
+// no nested sections or multi-line values.
+func parseINI(path string) (map[string]map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sections := map[string]map[string]string{}
+	cur := "default"
+	sections[cur] = map[string]string{}
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			cur = strings.TrimSpace(line[1 : len(line)-1])
+			if _, ok := sections[cur]; !ok {
+				sections[cur] = map[string]string{}
+			}
+			continue
+		}
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		key := strings.ToLower(strings.TrimSpace(kv[0]))
+		sections[cur][key] = strings.TrimSpace(kv[1])
+	}
+	return sections, sc.Err()
+}
+
+// loadConfigSections reads ~/.kno-url/config.ini then ./kno-url.ini,
+// merging section-by-section with the local file winning on conflict.
+// Missing files are not an error; profiles simply have nothing preset.
+func loadConfigSections() map[string]map[string]string {
+	configOnce.Do(func() {
+		merged := map[string]map[string]string{}
+
+		mergeFrom := func(path string) {
+			sections, err := parseINI(path)
+			if err != nil {
+				return
+			}
+			for name, kv := range sections {
+				if merged[name] == nil {
+					merged[name] = map[string]string{}
+				}
+				for k, v := range kv {
+					merged[name][k] = v
+				}
+			}
+		}
+
+		if home, err := os.UserHomeDir(); err == nil {
+			mergeFrom(filepath.Join(home, ".kno-url", "config.ini"))
+		}
+		mergeFrom("kno-url.ini")
+
+		configSections = merged
+	})
+	return configSections
+}
+
+// profileArgTokens turns a [default] or [profile.<name>] section into a
+// flag-token slice suitable for prepending to the real command-line args.
+func profileArgTokens(name string) ([]string, error) {
+	sections := loadConfigSections()
+
+	var section map[string]string
+	if name == "" {
+		section = sections["default"]
+	} else {
+		key := "profile." + name
+		s, ok := sections[key]
+		if !ok {
+			return nil, fmt.Errorf("Error: unknown profile %q (no [%s] section found)", name, key)
+		}
+		section = s
+	}
+	if section == nil {
+		return nil, nil
+	}
+
+	keys := make([]string, 0, len(section))
+	for k := range section {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var toks []string
+	for _, key := range keys {
+		val := section[key]
+		if key == "categories" {
+			for _, c := range strings.Split(val, ",") {
+				c = strings.ToLower(strings.TrimSpace(c))
+				if flag, ok := categoryShortFlag[c]; ok {
+					toks = append(toks, flag)
+				}
+			}
+			continue
+		}
+		flag, ok := iniKeyToFlag[key]
+		if !ok {
+			continue // unknown key; ignore rather than fail a whole profile
+		}
+		if booleanIniFlags[flag] {
+			if isTruthy(val) {
+				toks = append(toks, flag)
+			}
+			continue
+		}
+		toks = append(toks, flag, val)
+	}
+	return toks, nil
+}
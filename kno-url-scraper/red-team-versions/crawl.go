@@ -0,0 +1,478 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// -------- Recursive same-origin crawl mode (--crawl <depth>) --------
+
+// crawlConfig holds the crawl-specific flags parsed out of HTML mode args.
+// It's kept separate from htmlFilterConfig's category/search fields, which
+// still apply to what gets shown in the final grouped output.
+type crawlConfig struct {
+	depth        int    // 0 means crawling is disabled
+	scope        string // host | etld1 | any
+	includeCats  map[string]bool
+	ignoreRobots bool
+	rps          float64
+	concurrency  int
+	showSource   bool
+	maxPages     int
+}
+
+func defaultCrawlConfig() crawlConfig {
+	return crawlConfig{
+		scope:       "host",
+		rps:         2,
+		concurrency: 4,
+		maxPages:    100,
+	}
+}
+
+// parseCrawlFlags scans args for --crawl and its companions, returning the
+// config plus the leftover args for the regular HTML filter parser.
+func parseCrawlFlags(args []string) (crawlConfig, []string, error) {
+	cfg := defaultCrawlConfig()
+	var rest []string
+	i := 0
+	for i < len(args) {
+		a := args[i]
+		switch a {
+		case "--crawl":
+			if i+1 >= len(args) {
+				return cfg, rest, fmt.Errorf("Error: --crawl requires a depth, e.g. --crawl 2")
+			}
+			depth := 0
+			for _, r := range args[i+1] {
+				if r < '0' || r > '9' {
+					return cfg, rest, fmt.Errorf("Error: --crawl depth must be a non-negative integer")
+				}
+				depth = depth*10 + int(r-'0')
+			}
+			if depth <= 0 {
+				return cfg, rest, fmt.Errorf("Error: --crawl depth must be greater than 0")
+			}
+			cfg.depth = depth
+			i += 2
+		case "--crawl-scope":
+			if i+1 >= len(args) {
+				return cfg, rest, fmt.Errorf("Error: --crawl-scope requires host|etld1|any")
+			}
+			switch args[i+1] {
+			case "host", "etld1", "any":
+				cfg.scope = args[i+1]
+			default:
+				return cfg, rest, fmt.Errorf("Error: --crawl-scope must be host, etld1, or any")
+			}
+			i += 2
+		case "--crawl-include":
+			if i+1 >= len(args) {
+				return cfg, rest, fmt.Errorf("Error: --crawl-include requires a comma-separated category list")
+			}
+			cfg.includeCats = map[string]bool{}
+			for _, c := range strings.Split(args[i+1], ",") {
+				c = strings.TrimSpace(c)
+				if c != "" {
+					cfg.includeCats[strings.ToUpper(c)] = true
+				}
+			}
+			i += 2
+		case "--ignore-robots":
+			cfg.ignoreRobots = true
+			i++
+		case "--rps":
+			if i+1 >= len(args) {
+				return cfg, rest, fmt.Errorf("Error: --rps requires a number")
+			}
+			var v float64
+			if _, err := fmt.Sscanf(args[i+1], "%f", &v); err != nil || v <= 0 {
+				return cfg, rest, fmt.Errorf("Error: --rps requires a positive number")
+			}
+			cfg.rps = v
+			i += 2
+		case "--concurrency":
+			if i+1 >= len(args) {
+				return cfg, rest, fmt.Errorf("Error: --concurrency requires a positive integer")
+			}
+			var v int
+			if _, err := fmt.Sscanf(args[i+1], "%d", &v); err != nil || v <= 0 {
+				return cfg, rest, fmt.Errorf("Error: --concurrency requires a positive integer")
+			}
+			cfg.concurrency = v
+			i += 2
+		case "--show-source":
+			cfg.showSource = true
+			i++
+		case "--max-pages":
+			if i+1 >= len(args) {
+				return cfg, rest, fmt.Errorf("Error: --max-pages requires a positive integer")
+			}
+			var v int
+			if _, err := fmt.Sscanf(args[i+1], "%d", &v); err != nil || v <= 0 {
+				return cfg, rest, fmt.Errorf("Error: --max-pages requires a positive integer")
+			}
+			cfg.maxPages = v
+			i += 2
+		default:
+			rest = append(rest, a)
+			i++
+		}
+	}
+	return cfg, rest, nil
+}
+
+// visitedKey strips the fragment and sorts query keys so equivalent URLs
+// dedupe regardless of param ordering or a trailing #anchor.
+func visitedKey(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	u.Fragment = ""
+	if u.RawQuery != "" {
+		q := u.Query()
+		keys := make([]string, 0, len(q))
+		for k := range q {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var parts []string
+		for _, k := range keys {
+			for _, v := range q[k] {
+				parts = append(parts, k+"="+v)
+			}
+		}
+		u.RawQuery = strings.Join(parts, "&")
+	}
+	return u.String()
+}
+
+func sameScope(seed, candidate *url.URL, scope string) bool {
+	switch scope {
+	case "any":
+		return true
+	case "etld1":
+		return etld1(seed.Host) == etld1(candidate.Host)
+	default: // "host"
+		return seed.Host == candidate.Host
+	}
+}
+
+// etld1 is a best-effort "registrable domain" extraction (last two labels).
+// It doesn't consult the full public suffix list, which is fine for the
+// common case this flag is meant for (www.example.com vs cdn.example.com).
+func etld1(host string) string {
+	host = strings.ToLower(host)
+	if i := strings.IndexByte(host, ':'); i != -1 {
+		host = host[:i]
+	}
+	parts := strings.Split(host, ".")
+	if len(parts) <= 2 {
+		return host
+	}
+	return strings.Join(parts[len(parts)-2:], ".")
+}
+
+// -------- token-bucket rate limiting --------
+
+type tokenBucket struct {
+	mu       sync.Mutex
+	tokens   float64
+	max      float64
+	perSec   float64
+	lastFill time.Time
+}
+
+func newTokenBucket(rps float64) *tokenBucket {
+	return &tokenBucket{tokens: rps, max: rps, perSec: rps, lastFill: time.Now()}
+}
+
+func (tb *tokenBucket) take() {
+	for {
+		tb.mu.Lock()
+		now := time.Now()
+		elapsed := now.Sub(tb.lastFill).Seconds()
+		tb.tokens += elapsed * tb.perSec
+		if tb.tokens > tb.max {
+			tb.tokens = tb.max
+		}
+		tb.lastFill = now
+		if tb.tokens >= 1 {
+			tb.tokens--
+			tb.mu.Unlock()
+			return
+		}
+		tb.mu.Unlock()
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// -------- robots.txt --------
+
+type robotsRules struct {
+	disallow []string
+	sitemaps []string
+}
+
+var robotsCache = struct {
+	mu sync.Mutex
+	m  map[string]*robotsRules
+}{m: map[string]*robotsRules{}}
+
+func fetchRobots(scheme, host string) *robotsRules {
+	key := scheme + "://" + host
+	robotsCache.mu.Lock()
+	if r, ok := robotsCache.m[key]; ok {
+		robotsCache.mu.Unlock()
+		return r
+	}
+	robotsCache.mu.Unlock()
+
+	rules := &robotsRules{}
+	resp, err := http.Get(key + "/robots.txt")
+	if err == nil {
+		defer resp.Body.Close()
+		if resp.StatusCode == http.StatusOK {
+			rules = parseRobots(resp.Body)
+		}
+	}
+
+	robotsCache.mu.Lock()
+	robotsCache.m[key] = rules
+	robotsCache.mu.Unlock()
+	return rules
+}
+
+// parseRobots collects Disallow rules from the group matching our UA
+// ("KNO-URL-Scrapper") or, failing that, the wildcard "*" group.
+func parseRobots(body io.Reader) *robotsRules {
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return &robotsRules{}
+	}
+	lines := strings.Split(string(b), "\n")
+
+	type group struct {
+		agents   []string
+		disallow []string
+	}
+	var groups []*group
+	var g *group
+	var sitemaps []string
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		key := strings.ToLower(strings.TrimSpace(parts[0]))
+		val := strings.TrimSpace(parts[1])
+		switch key {
+		case "user-agent":
+			if g == nil || len(g.disallow) > 0 {
+				g = &group{}
+				groups = append(groups, g)
+			}
+			g.agents = append(g.agents, strings.ToLower(val))
+		case "disallow":
+			if g != nil && val != "" {
+				g.disallow = append(g.disallow, val)
+			}
+		case "sitemap":
+			// Sitemap: directives apply to the whole file, not a single group.
+			if val != "" {
+				sitemaps = append(sitemaps, val)
+			}
+		}
+	}
+
+	pick := func(agent string) []string {
+		for _, grp := range groups {
+			for _, a := range grp.agents {
+				if a == agent {
+					return grp.disallow
+				}
+			}
+		}
+		return nil
+	}
+
+	disallow := pick("kno-url-scrapper")
+	if disallow == nil {
+		disallow = pick("*")
+	}
+	return &robotsRules{disallow: disallow, sitemaps: sitemaps}
+}
+
+func (r *robotsRules) allows(path string) bool {
+	if r == nil {
+		return true
+	}
+	for _, d := range r.disallow {
+		if d == "" {
+			continue
+		}
+		if strings.HasPrefix(path, d) {
+			return false
+		}
+	}
+	return true
+}
+
+// -------- BFS crawl --------
+
+func runCrawl(seedURL string, htmlCfg htmlFilterConfig, crawlCfg crawlConfig) {
+	seed, err := url.Parse(seedURL)
+	if err != nil {
+		fmt.Printf("[-] Crawl: invalid seed URL: %v\n", err)
+		return
+	}
+
+	ua := userAgentPool.pick(htmlCfg.uaMode, htmlCfg.noUARefresh)
+	bucket := newTokenBucket(crawlCfg.rps)
+
+	visited := map[string]struct{}{visitedKey(seedURL): {}}
+	groupedSet := map[string]map[string]struct{}{
+		"SCRIPTS": {}, "MEDIA": {}, "API / ENDPOINTS": {},
+		"DOCUMENTS / CONFIG": {}, "HTML / FRAMEWORK": {}, "OTHER": {},
+	}
+	sourceOf := map[string]string{}
+
+	var mu sync.Mutex
+	pagesFetched := 0
+
+	frontier := []string{seedURL}
+	for d := 0; d <= crawlCfg.depth && len(frontier) > 0; d++ {
+		var next []string
+		sem := make(chan struct{}, crawlCfg.concurrency)
+		var wg sync.WaitGroup
+
+		for _, page := range frontier {
+			mu.Lock()
+			if pagesFetched >= crawlCfg.maxPages {
+				mu.Unlock()
+				break
+			}
+			pagesFetched++
+			mu.Unlock()
+
+			sem <- struct{}{}
+			wg.Add(1)
+			go func(page string) {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				pu, err := url.Parse(page)
+				if err != nil {
+					return
+				}
+				if !crawlCfg.ignoreRobots {
+					rules := fetchRobots(pu.Scheme, pu.Host)
+					if !rules.allows(pu.Path) {
+						fmt.Printf("[*] Skipping %s (robots.txt disallow)\n", page)
+						return
+					}
+				}
+
+				bucket.take()
+				fmt.Printf("[*] Crawling %s .\n", page)
+				body, err := fetchHTML(page, htmlCfg.insecureTLS, ua)
+				if err != nil {
+					fmt.Printf("[-] Crawl: fetch error for %s: %v\n", page, err)
+					return
+				}
+
+				urls, _ := extractURLsFromHTML(body, page)
+				mu.Lock()
+				for u := range urls {
+					if !matchesSearch(u, htmlCfg.searchTerms) {
+						continue
+					}
+					cat := categorizeURLHTML(u)
+					if htmlCfg.includeCategories != nil && !htmlCfg.includeCategories[cat] {
+						continue
+					}
+					if htmlCfg.excludeCategories[cat] {
+						continue
+					}
+					groupedSet[cat][u] = struct{}{}
+					if _, seen := sourceOf[u]; !seen {
+						sourceOf[u] = page
+					}
+
+					followable := cat == "HTML / FRAMEWORK"
+					if crawlCfg.includeCats != nil {
+						followable = crawlCfg.includeCats[cat]
+					}
+					if !followable {
+						continue
+					}
+					cu, err := url.Parse(u)
+					if err != nil || !sameScope(seed, cu, crawlCfg.scope) {
+						continue
+					}
+					key := visitedKey(u)
+					if _, seen := visited[key]; seen {
+						continue
+					}
+					visited[key] = struct{}{}
+					next = append(next, u)
+				}
+				mu.Unlock()
+			}(page)
+		}
+		wg.Wait()
+		frontier = next
+	}
+
+	grouped := map[string][]string{}
+	for cat, urls := range groupedSet {
+		for u := range urls {
+			grouped[cat] = append(grouped[cat], u)
+		}
+		sort.Strings(grouped[cat])
+	}
+
+	text, err := serializeGrouped(grouped, htmlCfg.format)
+	if err != nil {
+		fmt.Printf("[-] Failed to serialize results as %s: %v\n", htmlCfg.format, err)
+		return
+	}
+	if crawlCfg.showSource {
+		var lines []string
+		for _, line := range strings.Split(text, "\n") {
+			if src, ok := sourceOf[line]; ok {
+				lines = append(lines, fmt.Sprintf("%s  (from %s)", line, src))
+			} else {
+				lines = append(lines, line)
+			}
+		}
+		text = strings.Join(lines, "\n")
+	}
+
+	if text == "" {
+		fmt.Println("[*] No URLs matched the selected filters.")
+	} else {
+		fmt.Println(text)
+	}
+	fmt.Printf("[*] Crawl complete: %d page(s) fetched.\n", pagesFetched)
+
+	if htmlCfg.outputFile != "" {
+		if err := os.WriteFile(htmlCfg.outputFile, []byte(text+"\n"), 0644); err != nil {
+			fmt.Printf("[-] Failed to write to %s: %v\n", htmlCfg.outputFile, err)
+		} else {
+			fmt.Printf("[*] Results written to %s\n", htmlCfg.outputFile)
+		}
+	}
+}
@@ -102,6 +102,12 @@ type htmlFilterConfig struct {
 	fullMode          bool
 	searchTerms       []string // lowercase substrings
 	insecureTLS       bool
+	uaMode            string // "" means static (original hardcoded UA)
+	noUARefresh       bool
+	sitemapMode       bool
+	sitemapOnly       bool
+	sitemapMax        int
+	format            string // "" means text (default)
 }
 
 func printHelp() {
@@ -131,6 +137,10 @@ Night Ops:
   Main URL: --night-ops
   Main URL: https://example.com -n -t 60 --night-ops -sd 5m
 
+Profiles:
+  Main URL: cnn.com -p cdn-only
+  (presets flags from ~/.kno-url/config.ini or ./kno-url.ini; see -p below)
+
 Flags (HTML mode):
   -o <file>        Write output to file
   -s               Include SCRIPTS
@@ -144,13 +154,30 @@ Flags (HTML mode):
   --full           Print full HTML (like curl). Ignores categories and --search.
   -u <url>         Explicit URL token
   --insecure       Skip TLS verification (useful for broken cert chains)
+  --ua <mode>      User-Agent strategy: static|rotate|firefox|chrome, or a literal UA string (default: static)
+  --no-ua-refresh  Use the cached caniuse UA snapshot (~/.kno-url/ua-cache.json) instead of refreshing it
+  --crawl <depth>  BFS-crawl same-origin pages up to <depth> links deep, merging results
+  --crawl-scope <host|etld1|any>  Scope for "same-origin" during crawl (default: host)
+  --crawl-include <cats>          Comma-separated categories to follow (default: HTML / FRAMEWORK only)
+  --ignore-robots  Don't consult robots.txt while crawling
+  --rps <n>        Crawl request rate limit, requests/sec (default: 2)
+  --concurrency <n> Crawl worker pool size (default: 4)
+  --show-source    Annotate each crawled URL with the page it was discovered on (--format text only)
+  --max-pages <n>  Cap total pages fetched while crawling (default: 100)
+  --sitemap        Also pull robots.txt Sitemap: entries (or /sitemap.xml) into the results
+  --sitemap-only   Skip the HTML fetch entirely; enumerate only via sitemap.xml/robots.txt
+  --sitemap-max <n> Cap total sitemap URLs pulled (default: 1000)
+  --format <fmt>   Output format: text (default), json, ndjson, or csv
 
 Flags (Network mode with -n):
   -n               Enable network capture mode
   -t <duration>    Duration (e.g. 30, 45s, 2m, 1h30m). Required unless --live.
   --live           Live mode; print requests as they happen until you Ctrl+C
   -o <file>        Write network summary to file
+  --har <file>     Record a HAR 1.2 archive of the capture
+  --json <file>    Write structured per-request records (url, method, resourceType, status, mime, size, timing) as JSON
   --search <terms> Comma-separated substrings (mp4,cdn). URL must match at least one.
+  --format <fmt>   Output format: text (default), json, ndjson, or csv
   Resource filters (can combine):
     -fx   Fetch/XHR
     -d    Doc
@@ -168,6 +195,13 @@ Night Ops:
   --night-ops      Attempt local cleanup of this tool + .kno-url cache dirs
   -sd <duration>   Schedule night-ops after run (e.g. 5m, 1h30m). Requires --night-ops.
 
+Config & profiles:
+  -p, --profile <name>  Preset flags from a [profile.<name>] section in
+                         ~/.kno-url/config.ini or ./kno-url.ini (local file
+                         wins on key conflicts). A [default] section, if
+                         present, always applies. Flags typed on the command
+                         line take precedence over anything a profile sets.
+
 Help:
   -h, --help       Help
 
@@ -175,9 +209,13 @@ Notes:
   • If no HTML category flags are provided, all categories are included.
   • Sorting (HTML): within each category, URLs with extensions are grouped and
     sorted by extension (then URL). URLs without an extension are listed after.
-  • Network mode requires playwright-go and browsers installed:
-      go get github.com/playwright-community/playwright-go
-      # then follow the repo docs to run "playwright install" for your platform.
+  • Network mode requires Playwright's browsers installed locally. The
+    playwright-go module itself is already pinned in go.mod, so "go build"
+    is reproducible out of the box; just run "playwright install" (or
+    "go run github.com/playwright-community/playwright-go/cmd/playwright install")
+    once per machine before using -n.
+  • blob: URLs surfaced as a warning in HTML mode are captured for real in
+    network mode (grouped under Media).
 `))
 }
 
@@ -235,6 +273,7 @@ func parseHTMLFilters(args []string) (htmlFilterConfig, error) {
 	cfg := htmlFilterConfig{
 		includeCategories: map[string]bool{},
 		excludeCategories: map[string]bool{},
+		sitemapMax:        1000,
 	}
 	i := 0
 	for i < len(args) {
@@ -273,6 +312,46 @@ func parseHTMLFilters(args []string) (htmlFilterConfig, error) {
 		case "--insecure":
 			cfg.insecureTLS = true
 			i++
+		case "--ua":
+			if i+1 >= len(args) {
+				return cfg, fmt.Errorf("Error: --ua requires a mode (static|rotate|firefox|chrome) or a literal UA string")
+			}
+			mode, uaErr := parseUAFlagValue(args[i+1])
+			if uaErr != nil {
+				return cfg, uaErr
+			}
+			cfg.uaMode = mode
+			i += 2
+		case "--no-ua-refresh":
+			cfg.noUARefresh = true
+			i++
+		case "--sitemap":
+			cfg.sitemapMode = true
+			i++
+		case "--sitemap-only":
+			cfg.sitemapMode = true
+			cfg.sitemapOnly = true
+			i++
+		case "--sitemap-max":
+			if i+1 >= len(args) {
+				return cfg, fmt.Errorf("Error: --sitemap-max requires a positive integer")
+			}
+			var v int
+			if _, err := fmt.Sscanf(args[i+1], "%d", &v); err != nil || v <= 0 {
+				return cfg, fmt.Errorf("Error: --sitemap-max requires a positive integer")
+			}
+			cfg.sitemapMax = v
+			i += 2
+		case "--format":
+			if i+1 >= len(args) {
+				return cfg, fmt.Errorf("Error: --format requires text, json, ndjson, or csv")
+			}
+			f, ferr := validateFormat(args[i+1])
+			if ferr != nil {
+				return cfg, ferr
+			}
+			cfg.format = f
+			i += 2
 		default:
 			if strings.HasPrefix(a, "-") {
 				// category flags
@@ -307,7 +386,7 @@ func parseHTMLFilters(args []string) (htmlFilterConfig, error) {
 	return cfg, nil
 }
 
-func fetchHTML(target string, insecure bool) (string, error) {
+func fetchHTML(target string, insecure bool, userAgent string) (string, error) {
 	client := &http.Client{
 		Timeout: 20 * time.Second,
 	}
@@ -321,7 +400,10 @@ func fetchHTML(target string, insecure bool) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	req.Header.Set("User-Agent", "KNO-URL-Scrapper/Go-HTML/1.0")
+	if userAgent == "" {
+		userAgent = staticUA
+	}
+	req.Header.Set("User-Agent", userAgent)
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -482,6 +564,12 @@ func renderGrouped(grouped map[string][]string) string {
 }
 
 func handleHTML(urlStr string, args []string) {
+	crawlCfg, args, err := parseCrawlFlags(args)
+	if err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+
 	cfg, err := parseHTMLFilters(args)
 	if err != nil {
 		fmt.Println(err.Error())
@@ -490,27 +578,55 @@ func handleHTML(urlStr string, args []string) {
 
 	urlStr = normalizeURLCandidate(urlStr)
 
-	fmt.Printf("[*] Fetching HTML from %s .\n", urlStr)
-	htmlStr, err := fetchHTML(urlStr, cfg.insecureTLS)
-	if err != nil {
-		fmt.Printf("[-] Fetch error: %v\n", err)
+	if crawlCfg.depth > 0 {
+		if crawlCfg.showSource && cfg.format != "" && cfg.format != formatText {
+			fmt.Println("Error: --show-source only annotates --format text output; drop --show-source or use --format text.")
+			return
+		}
+		runCrawl(urlStr, cfg, crawlCfg)
 		return
 	}
 
-	if cfg.fullMode {
-		// --full ignores categories and search
-		if cfg.outputFile != "" {
-			if werr := os.WriteFile(cfg.outputFile, []byte(htmlStr), 0644); werr != nil {
-				fmt.Printf("[-] Failed to write full HTML to %s: %v\n", cfg.outputFile, werr)
-			} else {
-				fmt.Printf("[*] Full HTML written to %s\n", cfg.outputFile)
+	var htmlStr string
+	urlSet := map[string]bool{}
+	blobPresent := false
+
+	if !cfg.sitemapOnly {
+		ua := userAgentPool.pick(cfg.uaMode, cfg.noUARefresh)
+		fmt.Printf("[*] Fetching HTML from %s .\n", urlStr)
+		var err error
+		htmlStr, err = fetchHTML(urlStr, cfg.insecureTLS, ua)
+		if err != nil {
+			fmt.Printf("[-] Fetch error: %v\n", err)
+			return
+		}
+
+		if cfg.fullMode {
+			// --full ignores categories and search
+			if cfg.outputFile != "" {
+				if werr := os.WriteFile(cfg.outputFile, []byte(htmlStr), 0644); werr != nil {
+					fmt.Printf("[-] Failed to write full HTML to %s: %v\n", cfg.outputFile, werr)
+				} else {
+					fmt.Printf("[*] Full HTML written to %s\n", cfg.outputFile)
+				}
 			}
+			fmt.Println(htmlStr)
+			return
 		}
-		fmt.Println(htmlStr)
-		return
+
+		urlSet, blobPresent = extractURLsFromHTML(htmlStr, urlStr)
 	}
 
-	urlSet, blobPresent := extractURLsFromHTML(htmlStr, urlStr)
+	if cfg.sitemapMode {
+		fmt.Printf("[*] Discovering sitemap URLs for %s .\n", urlStr)
+		smURLs, err := discoverSitemapURLs(urlStr, cfg.sitemapMax)
+		if err != nil {
+			fmt.Printf("[!] Sitemap discovery failed: %v\n", err)
+		}
+		for u := range smURLs {
+			urlSet[u] = true
+		}
+	}
 
 	grouped := map[string][]string{
 		"SCRIPTS":            {},
@@ -537,7 +653,11 @@ func handleHTML(urlStr string, args []string) {
 		grouped[cat] = append(grouped[cat], u)
 	}
 
-	text := renderGrouped(grouped)
+	text, err := serializeGrouped(grouped, cfg.format)
+	if err != nil {
+		fmt.Printf("[-] Failed to serialize results as %s: %v\n", cfg.format, err)
+		return
+	}
 	if text == "" {
 		fmt.Println("[*] No URLs matched the selected filters.")
 	} else {
@@ -652,25 +772,7 @@ func mapResourceTypeToGroup(rt, reqURL string) string {
 	}
 }
 
-func runNetworkMode(urlStr string, args []string) {
-	urlStr = normalizeURLCandidate(urlStr)
-	warnIfMixedHTMLFlagsInNetwork(args)
-
-	fmt.Println("[-] Network mode (-n) is not available in this build because Playwright is not linked.")
-	fmt.Println()
-	fmt.Println("    To enable network mode, you have two options:")
-	fmt.Println()
-	fmt.Println("    1) Build from source with the Playwright Go bindings:")
-	fmt.Println("         go mod init example.com/kno-url        # if you don't have a go.mod yet")
-	fmt.Println("         go get github.com/playwright-community/playwright-go")
-	fmt.Println("         go mod tidy")
-	fmt.Println("         go build -o kno-url kno-url.go")
-	fmt.Println()
-	fmt.Println("       Then follow the playwright-go documentation to install the browsers.")
-	fmt.Println()
-	fmt.Println("    2) Keep using this binary for HTML mode only (no -n).")
-}
-
+// runNetworkMode lives in network.go (Playwright-backed capture).
 
 // -------- Night Ops cleanup --------
 
@@ -768,6 +870,32 @@ func handleCommand(line string) {
 		return
 	}
 
+	// Handle -p/--profile: merge config.ini/kno-url.ini preset flags ahead of
+	// the command line, so the CLI (appended last) wins on conflict. A
+	// [default] section, if present, applies even when no -p is given.
+	profileName := ""
+	for _, flagName := range []string{"-p", "--profile"} {
+		idx := indexOf(args, flagName)
+		if idx == -1 {
+			continue
+		}
+		if idx+1 >= len(args) {
+			fmt.Printf("Error: %s requires a profile name\n", flagName)
+			return
+		}
+		profileName = args[idx+1]
+		args = append(append([]string{}, args[:idx]...), args[idx+2:]...)
+		break
+	}
+	profileTokens, err := profileArgTokens(profileName)
+	if err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+	if len(profileTokens) > 0 {
+		args = append(profileTokens, args...)
+	}
+
 	// Detect global flags
 	nightOpsPresent := false
 	for _, a := range args {
@@ -0,0 +1,295 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/playwright-community/playwright-go"
+)
+
+// -------- Network mode (Playwright-backed capture) --------
+
+type networkFilterConfig struct {
+	includeTypes map[string]bool // nil means all groups
+	searchTerms  []string
+	outputFile   string
+	harFile      string
+	jsonFile     string
+	live         bool
+	seconds      int
+	format       string // "" means text (default)
+}
+
+// networkRecord is the structured per-request record written by --json.
+type networkRecord struct {
+	URL      string `json:"url"`
+	Method   string `json:"method"`
+	Group    string `json:"resourceType"`
+	Status   int    `json:"status"`
+	MIME     string `json:"mime"`
+	Size     int64  `json:"size"`
+	TimingMS int64  `json:"timingMs"`
+}
+
+func parseNetworkFilters(args []string) (networkFilterConfig, error) {
+	cfg := networkFilterConfig{includeTypes: map[string]bool{}}
+	i := 0
+	for i < len(args) {
+		a := args[i]
+		switch a {
+		case "-o":
+			if i+1 >= len(args) {
+				return cfg, fmt.Errorf("Error: -o requires a filename")
+			}
+			cfg.outputFile = args[i+1]
+			i += 2
+		case "--har":
+			if i+1 >= len(args) {
+				return cfg, fmt.Errorf("Error: --har requires a filename")
+			}
+			cfg.harFile = args[i+1]
+			i += 2
+		case "--json":
+			if i+1 >= len(args) {
+				return cfg, fmt.Errorf("Error: --json requires a filename")
+			}
+			cfg.jsonFile = args[i+1]
+			i += 2
+		case "--live":
+			cfg.live = true
+			i++
+		case "-t":
+			if i+1 >= len(args) {
+				return cfg, fmt.Errorf("Error: -t requires a duration like '30', '45s', or '1m30s'")
+			}
+			sec, err := parseDurationToSeconds(args[i+1])
+			if err != nil {
+				return cfg, fmt.Errorf("Error: invalid -t duration: %v", err)
+			}
+			cfg.seconds = sec
+			i += 2
+		case "--search":
+			if i+1 >= len(args) || strings.HasPrefix(args[i+1], "-") {
+				return cfg, fmt.Errorf("Error: --search requires a value like 'mp4' or 'mp4,cdn'")
+			}
+			var terms []string
+			for _, p := range strings.Split(args[i+1], ",") {
+				t := strings.ToLower(strings.TrimSpace(p))
+				if t != "" {
+					terms = append(terms, t)
+				}
+			}
+			if len(terms) == 0 {
+				return cfg, fmt.Errorf("Error: --search requires at least one non-empty term")
+			}
+			cfg.searchTerms = terms
+			i += 2
+		case "--format":
+			if i+1 >= len(args) {
+				return cfg, fmt.Errorf("Error: --format requires text, json, ndjson, or csv")
+			}
+			f, err := validateFormat(args[i+1])
+			if err != nil {
+				return cfg, err
+			}
+			cfg.format = f
+			i += 2
+		default:
+			if strings.HasPrefix(a, "-") {
+				if group, ok := flagToNetType[a]; ok {
+					cfg.includeTypes[group] = true
+					i++
+				} else {
+					return cfg, fmt.Errorf("Error: Unknown flag: %s. Try -h for help.", a)
+				}
+			} else {
+				i++
+			}
+		}
+	}
+
+	if !cfg.live && cfg.seconds == 0 {
+		return cfg, fmt.Errorf("Error: network mode requires -t <duration> unless --live is set")
+	}
+	if len(cfg.includeTypes) == 0 {
+		cfg.includeTypes = nil // all
+	}
+	return cfg, nil
+}
+
+func (cfg networkFilterConfig) matches(rec networkRecord) bool {
+	if cfg.includeTypes != nil && !cfg.includeTypes[rec.Group] {
+		return false
+	}
+	if !matchesSearch(rec.URL, cfg.searchTerms) {
+		return false
+	}
+	return true
+}
+
+func formatNetworkRecord(rec networkRecord) string {
+	return fmt.Sprintf("[%s] %s %s (status %d, %s, %d bytes)", rec.Group, rec.Method, rec.URL, rec.Status, rec.MIME, rec.Size)
+}
+
+func runNetworkMode(urlStr string, args []string) {
+	urlStr = normalizeURLCandidate(urlStr)
+	warnIfMixedHTMLFlagsInNetwork(args)
+
+	cfg, err := parseNetworkFilters(args)
+	if err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+
+	pw, err := playwright.Run()
+	if err != nil {
+		fmt.Printf("[-] Network mode: failed to start Playwright: %v\n", err)
+		fmt.Println("    Run 'playwright install' (see playwright-go docs) and try again.")
+		return
+	}
+	defer pw.Stop()
+
+	browser, err := pw.Chromium.Launch(playwright.BrowserTypeLaunchOptions{
+		Headless: playwright.Bool(true),
+	})
+	if err != nil {
+		fmt.Printf("[-] Network mode: failed to launch Chromium: %v\n", err)
+		return
+	}
+	defer browser.Close()
+
+	ctxOpts := playwright.BrowserNewContextOptions{}
+	if cfg.harFile != "" {
+		ctxOpts.RecordHarPath = playwright.String(cfg.harFile)
+	}
+	pctx, err := browser.NewContext(ctxOpts)
+	if err != nil {
+		fmt.Printf("[-] Network mode: failed to create browser context: %v\n", err)
+		return
+	}
+	defer pctx.Close()
+
+	page, err := pctx.NewPage()
+	if err != nil {
+		fmt.Printf("[-] Network mode: failed to open page: %v\n", err)
+		return
+	}
+
+	var records []networkRecord
+	started := map[string]time.Time{}
+
+	record := func(group, method, reqURL string, status int, mime string, size int64, startedAt time.Time) {
+		rec := networkRecord{
+			URL:      reqURL,
+			Method:   method,
+			Group:    group,
+			Status:   status,
+			MIME:     mime,
+			Size:     size,
+			TimingMS: time.Since(startedAt).Milliseconds(),
+		}
+		if !cfg.matches(rec) {
+			return
+		}
+		records = append(records, rec)
+		if cfg.live {
+			fmt.Println(formatNetworkRecord(rec))
+		}
+	}
+
+	page.On("request", func(req playwright.Request) {
+		started[req.URL()] = time.Now()
+		group := mapResourceTypeToGroup(req.ResourceType(), req.URL())
+		if strings.HasPrefix(req.URL(), "blob:") {
+			group = "Media"
+		}
+		if cfg.live {
+			// live mode prints on response (once status/size are known); nothing to do here.
+			return
+		}
+		_ = group
+	})
+
+	page.On("response", func(resp playwright.Response) {
+		req := resp.Request()
+		group := mapResourceTypeToGroup(req.ResourceType(), req.URL())
+		if strings.HasPrefix(req.URL(), "blob:") {
+			group = "Media"
+		}
+		startedAt, ok := started[req.URL()]
+		if !ok {
+			startedAt = time.Now()
+		}
+		var size int64
+		if body, err := resp.Body(); err == nil {
+			size = int64(len(body))
+		}
+		mime := ""
+		if headers, err := resp.AllHeaders(); err == nil {
+			mime = headers["content-type"]
+		}
+		record(group, req.Method(), req.URL(), resp.Status(), mime, size, startedAt)
+	})
+
+	page.On("websocket", func(ws playwright.WebSocket) {
+		record("Socket", "GET", ws.URL(), 101, "", 0, time.Now())
+	})
+
+	fmt.Printf("[*] Navigating to %s for network capture .\n", urlStr)
+	if _, err := page.Goto(urlStr); err != nil {
+		fmt.Printf("[-] Network mode: navigation failed: %v\n", err)
+		return
+	}
+
+	if cfg.live {
+		fmt.Println("[*] Live capture running; press Ctrl+C to stop.")
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+		<-sigCh
+		fmt.Println("\n[*] Stopping live capture.")
+	} else {
+		time.Sleep(time.Duration(cfg.seconds) * time.Second)
+	}
+
+	formatted, ferr := serializeNetworkRecords(records, cfg.format)
+	if ferr != nil {
+		fmt.Printf("[-] Failed to serialize results as %s: %v\n", cfg.format, ferr)
+		return
+	}
+
+	if !cfg.live {
+		if formatted == "" {
+			fmt.Println("[*] No requests matched the selected filters.")
+		} else {
+			fmt.Println(formatted)
+		}
+	}
+
+	if cfg.outputFile != "" {
+		if err := os.WriteFile(cfg.outputFile, []byte(formatted+"\n"), 0644); err != nil {
+			fmt.Printf("[-] Failed to write to %s: %v\n", cfg.outputFile, err)
+		} else {
+			fmt.Printf("[*] Results written to %s\n", cfg.outputFile)
+		}
+	}
+
+	if cfg.jsonFile != "" {
+		b, err := json.MarshalIndent(records, "", "  ")
+		if err != nil {
+			fmt.Printf("[-] Failed to encode JSON: %v\n", err)
+		} else if err := os.WriteFile(cfg.jsonFile, b, 0644); err != nil {
+			fmt.Printf("[-] Failed to write %s: %v\n", cfg.jsonFile, err)
+		} else {
+			fmt.Printf("[*] JSON capture written to %s\n", cfg.jsonFile)
+		}
+	}
+
+	if cfg.harFile != "" {
+		fmt.Printf("[*] HAR archive written to %s\n", cfg.harFile)
+	}
+}
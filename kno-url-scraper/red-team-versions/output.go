@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// -------- Structured output formats (--format json|ndjson|csv) --------
+//
+// The default ("text" or unset) keeps using renderGrouped/formatNetworkRecord
+// for backward compatibility; these formats give downstream tooling (jq,
+// xsv, …) something easier to consume than the grouped text blob.
+
+const (
+	formatText   = "text"
+	formatJSON   = "json"
+	formatNDJSON = "ndjson"
+	formatCSV    = "csv"
+)
+
+func validateFormat(f string) (string, error) {
+	switch f {
+	case "", formatText, formatJSON, formatNDJSON, formatCSV:
+		if f == "" {
+			return formatText, nil
+		}
+		return f, nil
+	default:
+		return "", fmt.Errorf("Error: --format must be one of text, json, ndjson, csv")
+	}
+}
+
+// urlRecord is one HTML-mode URL, flattened for json/ndjson/csv output.
+type urlRecord struct {
+	URL      string `json:"url"`
+	Category string `json:"category,omitempty"`
+	Ext      string `json:"ext"`
+	Host     string `json:"host"`
+	Path     string `json:"path"`
+	HasQuery bool   `json:"has_query,omitempty"`
+}
+
+func toURLRecord(u, category string) urlRecord {
+	rec := urlRecord{URL: u, Category: category}
+	pu, err := url.Parse(u)
+	if err != nil {
+		return rec
+	}
+	rec.Ext = strings.ToLower(path.Ext(pu.Path))
+	rec.Host = pu.Host
+	rec.Path = pu.Path
+	rec.HasQuery = pu.RawQuery != ""
+	return rec
+}
+
+// serializeGrouped renders grouped HTML-mode results in the requested
+// format. "text" (or "") delegates to the existing renderGrouped.
+func serializeGrouped(grouped map[string][]string, format string) (string, error) {
+	order := []string{"SCRIPTS", "MEDIA", "API / ENDPOINTS", "DOCUMENTS / CONFIG", "HTML / FRAMEWORK", "OTHER"}
+
+	switch format {
+	case "", formatText:
+		return renderGrouped(grouped), nil
+
+	case formatJSON:
+		out := map[string][]urlRecord{}
+		for _, cat := range order {
+			for _, u := range grouped[cat] {
+				out[cat] = append(out[cat], toURLRecord(u, ""))
+			}
+		}
+		b, err := json.MarshalIndent(out, "", "  ")
+		return string(b), err
+
+	case formatNDJSON:
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		for _, cat := range order {
+			for _, u := range grouped[cat] {
+				if err := enc.Encode(toURLRecord(u, cat)); err != nil {
+					return "", err
+				}
+			}
+		}
+		return strings.TrimRight(buf.String(), "\n"), nil
+
+	case formatCSV:
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		if err := w.Write([]string{"category", "url", "host", "ext", "has_query"}); err != nil {
+			return "", err
+		}
+		for _, cat := range order {
+			for _, u := range grouped[cat] {
+				rec := toURLRecord(u, cat)
+				if err := w.Write([]string{
+					cat, rec.URL, rec.Host, rec.Ext, fmt.Sprintf("%t", rec.HasQuery),
+				}); err != nil {
+					return "", err
+				}
+			}
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return "", err
+		}
+		return strings.TrimRight(buf.String(), "\n"), nil
+
+	default:
+		return "", fmt.Errorf("unsupported format: %s", format)
+	}
+}
+
+// serializeNetworkRecords renders network-mode capture records in the
+// requested format, mirroring serializeGrouped's shape for consistency.
+func serializeNetworkRecords(records []networkRecord, format string) (string, error) {
+	switch format {
+	case "", formatText:
+		var lines []string
+		for _, rec := range records {
+			lines = append(lines, formatNetworkRecord(rec))
+		}
+		return strings.Join(lines, "\n"), nil
+
+	case formatJSON:
+		b, err := json.MarshalIndent(records, "", "  ")
+		return string(b), err
+
+	case formatNDJSON:
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		for _, rec := range records {
+			if err := enc.Encode(rec); err != nil {
+				return "", err
+			}
+		}
+		return strings.TrimRight(buf.String(), "\n"), nil
+
+	case formatCSV:
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		if err := w.Write([]string{"category", "url", "method", "status", "mime", "size"}); err != nil {
+			return "", err
+		}
+		for _, rec := range records {
+			if err := w.Write([]string{
+				rec.Group, rec.URL, rec.Method,
+				fmt.Sprintf("%d", rec.Status), rec.MIME, fmt.Sprintf("%d", rec.Size),
+			}); err != nil {
+				return "", err
+			}
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return "", err
+		}
+		return strings.TrimRight(buf.String(), "\n"), nil
+
+	default:
+		return "", fmt.Errorf("unsupported format: %s", format)
+	}
+}
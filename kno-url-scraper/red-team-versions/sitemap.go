@@ -0,0 +1,104 @@
+package main
+
+import (
+	"compress/gzip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// -------- sitemap.xml / robots.txt discovery (--sitemap / --sitemap-only) --------
+
+// sitemapDoc matches both <urlset> (leaf sitemaps) and <sitemapindex>
+// (sitemaps-of-sitemaps) documents, since xml.Unmarshal only cares about
+// child element names, not the root.
+type sitemapDoc struct {
+	XMLName xml.Name
+	URLs    []struct {
+		Loc string `xml:"loc"`
+	} `xml:"url"`
+	Sitemaps []struct {
+		Loc string `xml:"loc"`
+	} `xml:"sitemap"`
+}
+
+func fetchSitemapDoc(sitemapURL string) (*sitemapDoc, error) {
+	resp, err := http.Get(sitemapURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	var r io.Reader = resp.Body
+	if strings.HasSuffix(strings.ToLower(sitemapURL), ".xml.gz") || resp.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("gunzip %s: %w", sitemapURL, err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	var doc sitemapDoc
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", sitemapURL, err)
+	}
+	return &doc, nil
+}
+
+// discoverSitemapURLs walks robots.txt Sitemap: entries (falling back to
+// /sitemap.xml) and any nested <sitemapindex> documents, returning at most
+// max <loc> URLs.
+func discoverSitemapURLs(baseURL string, max int) (map[string]bool, error) {
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := fetchRobots(base.Scheme, base.Host)
+	queue := append([]string{}, rules.sitemaps...)
+	if len(queue) == 0 {
+		queue = []string{base.Scheme + "://" + base.Host + "/sitemap.xml"}
+	}
+
+	seen := map[string]bool{}
+	found := map[string]bool{}
+
+	for len(queue) > 0 && len(found) < max {
+		sm := queue[0]
+		queue = queue[1:]
+		if seen[sm] {
+			continue
+		}
+		seen[sm] = true
+
+		doc, err := fetchSitemapDoc(sm)
+		if err != nil {
+			fmt.Printf("[*] Sitemap: skipping %s (%v)\n", sm, err)
+			continue
+		}
+
+		for _, s := range doc.Sitemaps {
+			if s.Loc != "" && !seen[s.Loc] {
+				queue = append(queue, s.Loc)
+			}
+		}
+		for _, u := range doc.URLs {
+			if u.Loc == "" {
+				continue
+			}
+			found[u.Loc] = true
+			if len(found) >= max {
+				break
+			}
+		}
+	}
+
+	return found, nil
+}
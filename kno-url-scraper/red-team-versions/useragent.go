@@ -0,0 +1,321 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// -------- User-Agent rotation pool --------
+//
+// Picks a realistic, currently-in-use Chrome/Firefox UA string per request
+// instead of the old static "KNO-URL-Scrapper/Go-HTML/1.0". Usage shares are
+// sourced from caniuse's fulldata-json/data-2.0.json dataset and cached
+// on disk so repeat runs (and offline/air-gapped runs) don't need network
+// access every time.
+
+const (
+	caniuseDataURL  = "https://raw.githubusercontent.com/Fyrd/caniuse/main/fulldata-json/data-2.0.json"
+	uaCacheMaxAge   = 24 * time.Hour
+	uaPoolTopN      = 5
+	uaUsageMinShare = 0.5 // percent; versions below this share are dropped
+)
+
+// staticUA is the original fixed string, kept for --ua static.
+const staticUA = "KNO-URL-Scrapper/Go-HTML/1.0"
+
+// versionShare is one browser version and its global usage share, as pulled
+// from caniuse's "usage_global" maps.
+type versionShare struct {
+	Version string  `json:"version"`
+	Share   float64 `json:"share"`
+}
+
+// uaCacheFile is the on-disk snapshot stored at ~/.kno-url/ua-cache.json.
+type uaCacheFile struct {
+	FetchedAt time.Time      `json:"fetched_at"`
+	Firefox   []versionShare `json:"firefox"`
+	Chrome    []versionShare `json:"chrome"`
+}
+
+// fallbackUAPool is the bundled static list used when the caniuse fetch
+// fails and no usable cache exists, so the scraper still works air-gapped.
+var fallbackUAPool = uaCacheFile{
+	Firefox: []versionShare{
+		{Version: "127.0", Share: 2.1},
+		{Version: "115.0", Share: 1.2},
+	},
+	Chrome: []versionShare{
+		{Version: "126.0.0.0", Share: 6.4},
+		{Version: "125.0.0.0", Share: 3.8},
+		{Version: "124.0.0.0", Share: 2.2},
+	},
+}
+
+// userAgentPool is the package-level rotating UA source. It lazily loads
+// (and periodically refreshes) the caniuse snapshot on first use.
+var userAgentPool = &uaPool{}
+
+type uaPool struct {
+	mu   sync.Mutex
+	data uaCacheFile
+}
+
+func uaCachePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".kno-url", "ua-cache.json"), nil
+}
+
+func loadUACache() (uaCacheFile, error) {
+	path, err := uaCachePath()
+	if err != nil {
+		return uaCacheFile{}, err
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return uaCacheFile{}, err
+	}
+	var c uaCacheFile
+	if err := json.Unmarshal(b, &c); err != nil {
+		return uaCacheFile{}, err
+	}
+	return c, nil
+}
+
+func saveUACache(c uaCacheFile) error {
+	path, err := uaCachePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0644)
+}
+
+// fetchCaniuseUAData pulls the caniuse dataset and extracts the top-N
+// Firefox/Chrome versions by global usage share, above uaUsageMinShare.
+func fetchCaniuseUAData() (uaCacheFile, error) {
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Get(caniuseDataURL)
+	if err != nil {
+		return uaCacheFile{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return uaCacheFile{}, fmt.Errorf("caniuse fetch: unexpected status %s", resp.Status)
+	}
+
+	var payload struct {
+		Agents map[string]struct {
+			UsageGlobal map[string]float64 `json:"usage_global"`
+		} `json:"agents"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return uaCacheFile{}, fmt.Errorf("caniuse decode: %w", err)
+	}
+
+	pick := func(browser string) []versionShare {
+		agent, ok := payload.Agents[browser]
+		if !ok {
+			return nil
+		}
+		var all []versionShare
+		for v, share := range agent.UsageGlobal {
+			if share >= uaUsageMinShare {
+				all = append(all, versionShare{Version: v, Share: share})
+			}
+		}
+		sort.Slice(all, func(i, j int) bool { return all[i].Share > all[j].Share })
+		if len(all) > uaPoolTopN {
+			all = all[:uaPoolTopN]
+		}
+		return all
+	}
+
+	c := uaCacheFile{
+		FetchedAt: time.Now(),
+		Firefox:   pick("firefox"),
+		Chrome:    pick("chrome"),
+	}
+	if len(c.Firefox) == 0 && len(c.Chrome) == 0 {
+		return uaCacheFile{}, fmt.Errorf("caniuse data: no versions above %.1f%% share", uaUsageMinShare)
+	}
+	return c, nil
+}
+
+// ensureLoaded makes sure the pool has data, refreshing from caniuse (unless
+// noRefresh is set) when the cache is missing or older than uaCacheMaxAge.
+func (p *uaPool) ensureLoaded(noRefresh bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.data.Firefox) > 0 || len(p.data.Chrome) > 0 {
+		return
+	}
+
+	cached, err := loadUACache()
+	stale := err != nil || time.Since(cached.FetchedAt) > uaCacheMaxAge
+
+	if noRefresh {
+		if err == nil {
+			p.data = cached
+		} else {
+			p.data = fallbackUAPool
+		}
+		return
+	}
+
+	if !stale {
+		p.data = cached
+		return
+	}
+
+	fresh, ferr := fetchCaniuseUAData()
+	if ferr != nil {
+		if err == nil {
+			p.data = cached // fall back to whatever we had cached, even if stale
+		} else {
+			p.data = fallbackUAPool
+		}
+		return
+	}
+	p.data = fresh
+	_ = saveUACache(fresh) // best-effort; an unwritable cache dir shouldn't block scraping
+}
+
+func pickWeighted(versions []versionShare) string {
+	var total float64
+	for _, v := range versions {
+		total += v.Share
+	}
+	if total <= 0 {
+		return ""
+	}
+	r := rand.Float64() * total
+	for _, v := range versions {
+		r -= v.Share
+		if r <= 0 {
+			return v.Version
+		}
+	}
+	return versions[len(versions)-1].Version
+}
+
+// normalizeFirefoxVersion pads a caniuse bare-major version ("127") out to
+// the ".0" form real Firefox builds report ("127.0"); full versions pass
+// through unchanged.
+func normalizeFirefoxVersion(version string) string {
+	if !strings.Contains(version, ".") {
+		return version + ".0"
+	}
+	return version
+}
+
+// normalizeChromeVersion pads a caniuse bare-major version ("126") out to
+// the four-part form real Chrome builds report ("126.0.0.0"); full versions
+// pass through unchanged.
+func normalizeChromeVersion(version string) string {
+	if !strings.Contains(version, ".") {
+		return version + ".0.0.0"
+	}
+	return version
+}
+
+func firefoxUAString(version string) string {
+	version = normalizeFirefoxVersion(version)
+	return fmt.Sprintf("Mozilla/5.0 (X11; Linux x86_64; rv:%s) Gecko/20100101 Firefox/%s", version, version)
+}
+
+func chromeUAString(version string) string {
+	version = normalizeChromeVersion(version)
+	return fmt.Sprintf("Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/%s Safari/537.36", version)
+}
+
+// pick returns a UA string for the given mode: "static", "rotate" (either
+// browser, weighted), "firefox", "chrome", or a literal UA string passed
+// through as-is.
+func (p *uaPool) pick(mode string, noRefresh bool) string {
+	switch mode {
+	case "", "static":
+		return staticUA
+	case "firefox", "chrome", "rotate":
+		p.ensureLoaded(noRefresh)
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		switch mode {
+		case "firefox":
+			if v := pickWeighted(p.data.Firefox); v != "" {
+				return firefoxUAString(v)
+			}
+		case "chrome":
+			if v := pickWeighted(p.data.Chrome); v != "" {
+				return chromeUAString(v)
+			}
+		case "rotate":
+			var pool []struct {
+				build func(string) string
+				vs    []versionShare
+			}
+			pool = append(pool, struct {
+				build func(string) string
+				vs    []versionShare
+			}{firefoxUAString, p.data.Firefox})
+			pool = append(pool, struct {
+				build func(string) string
+				vs    []versionShare
+			}{chromeUAString, p.data.Chrome})
+			// weight the browser choice by its total share too
+			var totals []float64
+			var grand float64
+			for _, entry := range pool {
+				var t float64
+				for _, v := range entry.vs {
+					t += v.Share
+				}
+				totals = append(totals, t)
+				grand += t
+			}
+			if grand > 0 {
+				r := rand.Float64() * grand
+				for i, t := range totals {
+					r -= t
+					if r <= 0 {
+						if v := pickWeighted(pool[i].vs); v != "" {
+							return pool[i].build(v)
+						}
+						break
+					}
+				}
+			}
+		}
+		return staticUA
+	default:
+		// a literal UA string
+		return mode
+	}
+}
+
+// parseUAFlagValue validates the --ua mode/value, rejecting empty strings
+// that would otherwise silently fall back to "static".
+func parseUAFlagValue(v string) (string, error) {
+	if v == "" {
+		return "", fmt.Errorf("Error: --ua requires a mode (static|rotate|firefox|chrome) or a literal UA string")
+	}
+	return v, nil
+}